@@ -0,0 +1,150 @@
+// Package app implements the chunk-archive CLI, which exports finalized chunks to and
+// imports them from era1-style flat archive files.
+package app
+
+import (
+	"fmt"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/chunkarchive"
+	"scroll-tech/rollup/internal/l1client"
+	"scroll-tech/rollup/internal/orm"
+)
+
+var (
+	dbDSNFlag = &cli.StringFlag{
+		Name:     "db-dsn",
+		Usage:    "Postgres DSN of the database to read chunks from",
+		Required: true,
+	}
+	fromFlag = &cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "first chunk index (inclusive) to export",
+		Required: true,
+	}
+	toFlag = &cli.Uint64Flag{
+		Name:     "to",
+		Usage:    "last chunk index (inclusive) to export",
+		Required: true,
+	}
+	archiveFlag = &cli.StringFlag{
+		Name:     "archive",
+		Usage:    "path to the chunk archive file",
+		Required: true,
+	}
+	l1RPCFlag = &cli.StringSliceFlag{
+		Name:  "l1-rpc",
+		Usage: "L1 RPC endpoint(s) to re-derive L1 block range hashes from; if omitted, verify skips that check",
+	}
+	l1QuorumFlag = &cli.IntFlag{
+		Name:  "l1-quorum",
+		Usage: "minimum number of matching l1-rpc responses required",
+		Value: 1,
+	}
+	l1ViewOracleFlag = &cli.StringFlag{
+		Name:  "l1-view-oracle",
+		Usage: "address of the L1 view oracle contract, required if --l1-rpc is set",
+	}
+)
+
+// Run runs the chunk-archive CLI with args (typically os.Args).
+func Run(args []string) error {
+	cliApp := cli.NewApp()
+	cliApp.Name = "chunk-archive"
+	cliApp.Usage = "export, import, and verify era1-style chunk archive files"
+	cliApp.Commands = []*cli.Command{exportCommand, importCommand, verifyCommand}
+	return cliApp.Run(args)
+}
+
+var exportCommand = &cli.Command{
+	Name:  "export",
+	Usage: "export finalized chunks in [--from, --to] into an archive file",
+	Flags: []cli.Flag{dbDSNFlag, fromFlag, toFlag, archiveFlag},
+	Action: func(ctx *cli.Context) error {
+		db, err := openDB(ctx.String(dbDSNFlag.Name))
+		if err != nil {
+			return err
+		}
+
+		from, to, path := ctx.Uint64(fromFlag.Name), ctx.Uint64(toFlag.Name), ctx.String(archiveFlag.Name)
+		if err := chunkarchive.ExportChunks(ctx.Context, orm.NewChunk(db), orm.NewL2Block(db), from, to, path); err != nil {
+			return fmt.Errorf("failed to export chunks: %w", err)
+		}
+
+		fmt.Printf("exported chunks [%d, %d] to %s\n", from, to, path)
+		return nil
+	},
+}
+
+var importCommand = &cli.Command{
+	Name:  "import",
+	Usage: "list the chunks contained in an archive file",
+	Flags: []cli.Flag{archiveFlag},
+	Action: func(ctx *cli.Context) error {
+		chunks, err := chunkarchive.ImportChunks(ctx.String(archiveFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to import chunks: %w", err)
+		}
+
+		for _, c := range chunks {
+			fmt.Printf("chunk %d: blocks [%d, %d], hash %s\n", c.ChunkIndex, c.StartBlockNumber, c.EndBlockNumber, c.Hash)
+		}
+		return nil
+	},
+}
+
+var verifyCommand = &cli.Command{
+	Name: "verify",
+	Usage: "verify an archive file's checksum and per-chunk row consumption; pass --l1-rpc " +
+		"and --l1-view-oracle to also re-derive and check L1 block range hashes",
+	Flags: []cli.Flag{archiveFlag, l1RPCFlag, l1QuorumFlag, l1ViewOracleFlag},
+	Action: func(ctx *cli.Context) error {
+		path := ctx.String(archiveFlag.Name)
+
+		endpoints := ctx.StringSlice(l1RPCFlag.Name)
+		if len(endpoints) == 0 {
+			if err := chunkarchive.VerifyChecksum(path); err != nil {
+				return fmt.Errorf("checksum verification failed: %w", err)
+			}
+			chunks, err := chunkarchive.ImportChunks(path)
+			if err != nil {
+				return fmt.Errorf("failed to import chunks: %w", err)
+			}
+			for _, c := range chunks {
+				if _, _, err := chunkarchive.RecomputeRowConsumption(c); err != nil {
+					return fmt.Errorf("row consumption check failed for chunk %d: %w", c.ChunkIndex, err)
+				}
+			}
+			fmt.Printf("archive is valid: checksum OK, %d chunks' row consumption recomputed successfully (l1 block range hash not checked, --l1-rpc not set)\n", len(chunks))
+			return nil
+		}
+
+		if !ctx.IsSet(l1ViewOracleFlag.Name) {
+			return fmt.Errorf("--l1-view-oracle is required when --l1-rpc is set")
+		}
+		l1Client, err := l1client.New(l1client.Config{Endpoints: endpoints, Quorum: ctx.Int(l1QuorumFlag.Name)}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to connect to l1: %w", err)
+		}
+		hasher := chunkarchive.NewOracleHasher(l1Client, common.HexToAddress(ctx.String(l1ViewOracleFlag.Name)))
+
+		if err := chunkarchive.Verify(ctx.Context, path, hasher); err != nil {
+			return fmt.Errorf("archive verification failed: %w", err)
+		}
+
+		fmt.Println("archive is valid: checksum, row consumption, and l1 block range hashes all verified")
+		return nil
+	},
+}
+
+func openDB(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"os"
+
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"scroll-tech/rollup/cmd/chunk_archive/app"
+)
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("chunk-archive command failed", "err", err)
+	}
+}
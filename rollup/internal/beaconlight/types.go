@@ -0,0 +1,62 @@
+package beaconlight
+
+import (
+	"github.com/scroll-tech/go-ethereum/common"
+)
+
+// SyncPeriodLength is the number of beacon chain slots in a single sync committee period.
+const SyncPeriodLength = 8192
+
+// SyncCommitteeSize is the number of validators in a sync committee.
+const SyncCommitteeSize = 512
+
+// SyncCommittee is the set of validator public keys responsible for attesting to beacon
+// chain headers during a given sync period, plus their BLS aggregate pubkey.
+type SyncCommittee struct {
+	Pubkeys         [SyncCommitteeSize][48]byte
+	AggregatePubkey [48]byte
+}
+
+// ExecutionPayloadHeader is the subset of the execution-layer header committed to by a
+// beacon block, used to authenticate L1 execution blocks.
+type ExecutionPayloadHeader struct {
+	BlockNumber uint64
+	BlockHash   common.Hash
+	StateRoot   common.Hash
+}
+
+// Header is a beacon block header together with the execution payload it commits to.
+type Header struct {
+	Slot            uint64
+	ParentRoot      common.Hash
+	StateRoot       common.Hash
+	BodyRoot        common.Hash
+	ExecutionHeader ExecutionPayloadHeader
+}
+
+// SyncAggregate is the aggregate BLS signature and participation bitfield produced by
+// the sync committee attesting to a beacon header.
+type SyncAggregate struct {
+	SyncCommitteeBits      []byte
+	SyncCommitteeSignature [96]byte
+}
+
+// LightClientBootstrap is served once, for a trusted checkpoint root, to initialize a
+// light client follower with the sync committee active at that checkpoint.
+type LightClientBootstrap struct {
+	Header                     Header
+	CurrentSyncCommittee       SyncCommittee
+	CurrentSyncCommitteeBranch []common.Hash
+}
+
+// LightClientUpdate advances a follower's view of the chain: it proves a newer
+// attested/finalized header and, on period boundaries, the next sync committee.
+type LightClientUpdate struct {
+	AttestedHeader          Header
+	NextSyncCommittee       *SyncCommittee
+	NextSyncCommitteeBranch []common.Hash
+	FinalizedHeader         Header
+	FinalityBranch          []common.Hash
+	SyncAggregate           SyncAggregate
+	SignatureSlot           uint64
+}
@@ -0,0 +1,158 @@
+package beaconlight
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/crypto/bls12381"
+)
+
+func TestBitSet(t *testing.T) {
+	bits := []byte{0b00000101} // bits 0 and 2 set
+	for i, want := range []bool{true, false, true, false, false, false, false, false} {
+		if got := bitSet(bits, i); got != want {
+			t.Errorf("bitSet(bits, %d) = %v, want %v", i, got, want)
+		}
+	}
+	if bitSet(bits, 64) {
+		t.Error("bitSet should report false past the end of the slice, not panic")
+	}
+}
+
+// singleMemberCommittee builds a one-validator SyncCommittee and a matching signature
+// over root produced with secret key sk, exercising defaultAggregateVerify end to end
+// against this package's own hash-to-curve (see messageToG2).
+func singleMemberCommittee(t *testing.T, sk *big.Int, root common.Hash) (*SyncCommittee, SyncAggregate) {
+	t.Helper()
+
+	g1 := bls12381.NewG1()
+	pubkeyPoint := g1.New()
+	g1.MulScalar(pubkeyPoint, g1.One(), sk)
+
+	committee := &SyncCommittee{}
+	copy(committee.Pubkeys[0][:], g1.ToBytes(pubkeyPoint))
+
+	msg, err := messageToG2(root)
+	if err != nil {
+		t.Fatalf("messageToG2: %v", err)
+	}
+	g2 := bls12381.NewG2()
+	sigPoint := g2.New()
+	g2.MulScalar(sigPoint, msg, sk)
+
+	aggregate := SyncAggregate{SyncCommitteeBits: []byte{0b00000001}}
+	copy(aggregate.SyncCommitteeSignature[:], g2.ToBytes(sigPoint))
+
+	return committee, aggregate
+}
+
+func TestDefaultAggregateVerifyAccepts(t *testing.T) {
+	sk := big.NewInt(424242)
+	root := common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	committee, aggregate := singleMemberCommittee(t, sk, root)
+
+	if err := defaultAggregateVerify(committee, root, aggregate); err != nil {
+		t.Fatalf("expected a genuine signature to verify, got: %v", err)
+	}
+}
+
+func TestDefaultAggregateVerifyRejectsTamperedSignature(t *testing.T) {
+	sk := big.NewInt(424242)
+	root := common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	committee, aggregate := singleMemberCommittee(t, sk, root)
+
+	aggregate.SyncCommitteeSignature[0] ^= 0xff
+
+	if err := defaultAggregateVerify(committee, root, aggregate); err == nil {
+		t.Fatal("expected a tampered signature to be rejected")
+	}
+}
+
+func TestDefaultAggregateVerifyRejectsWrongRoot(t *testing.T) {
+	sk := big.NewInt(424242)
+	signedRoot := common.HexToHash("0x3333333333333333333333333333333333333333333333333333333333333333")
+	otherRoot := common.HexToHash("0x4444444444444444444444444444444444444444444444444444444444444444")
+	committee, aggregate := singleMemberCommittee(t, sk, signedRoot)
+
+	if err := defaultAggregateVerify(committee, otherRoot, aggregate); err == nil {
+		t.Fatal("expected verification against a different root to fail")
+	}
+}
+
+func TestDefaultAggregateVerifyRejectsNoParticipants(t *testing.T) {
+	committee := &SyncCommittee{}
+	aggregate := SyncAggregate{SyncCommitteeBits: []byte{0}}
+
+	if err := defaultAggregateVerify(committee, common.Hash{}, aggregate); err == nil {
+		t.Fatal("expected an empty participant set to be rejected")
+	}
+}
+
+func TestVerifyMerkleBranchAcceptsGenuineProof(t *testing.T) {
+	leaves := make([]common.Hash, 4)
+	for i := range leaves {
+		leaves[i] = common.BigToHash(big.NewInt(int64(i) + 1))
+	}
+	root := merkleize(leaves)
+
+	// Leaf index 2 (0-based) in a depth-2 tree has generalized index 4+2=6; its branch is
+	// [sibling leaf 3, sibling of the parent pair (hash of leaves 0,1)].
+	branch := []common.Hash{leaves[3], sha256Pair(leaves[0], leaves[1])}
+	if !verifyMerkleBranch(leaves[2], branch, 6, root) {
+		t.Fatal("expected a genuine merkle branch to verify")
+	}
+}
+
+func TestVerifyMerkleBranchRejectsTamperedLeaf(t *testing.T) {
+	leaves := make([]common.Hash, 4)
+	for i := range leaves {
+		leaves[i] = common.BigToHash(big.NewInt(int64(i) + 1))
+	}
+	root := merkleize(leaves)
+	branch := []common.Hash{leaves[3], sha256Pair(leaves[0], leaves[1])}
+
+	tampered := common.BigToHash(big.NewInt(999))
+	if verifyMerkleBranch(tampered, branch, 6, root) {
+		t.Fatal("expected a tampered leaf to fail verification")
+	}
+}
+
+func TestExpandMessageXMDIsDeterministicAndDomainSeparated(t *testing.T) {
+	out1, err := expandMessageXMD([]byte("hello"), []byte("dst-a"), 64)
+	if err != nil {
+		t.Fatalf("expandMessageXMD: %v", err)
+	}
+	out2, err := expandMessageXMD([]byte("hello"), []byte("dst-a"), 64)
+	if err != nil {
+		t.Fatalf("expandMessageXMD: %v", err)
+	}
+	if string(out1) != string(out2) {
+		t.Error("expandMessageXMD should be deterministic for the same inputs")
+	}
+
+	out3, err := expandMessageXMD([]byte("hello"), []byte("dst-b"), 64)
+	if err != nil {
+		t.Fatalf("expandMessageXMD: %v", err)
+	}
+	if string(out1) == string(out3) {
+		t.Error("expandMessageXMD should be domain-separated by dst")
+	}
+	if len(out1) != 64 {
+		t.Errorf("expandMessageXMD returned %d bytes, want 64", len(out1))
+	}
+}
+
+func TestComputeDomainDiffersByForkAndChain(t *testing.T) {
+	root := common.HexToHash("0x5555555555555555555555555555555555555555555555555555555555555555")
+	d1 := computeDomain([4]byte{1, 0, 0, 0}, root)
+	d2 := computeDomain([4]byte{2, 0, 0, 0}, root)
+	if d1 == d2 {
+		t.Error("computeDomain should differ across fork versions")
+	}
+
+	d3 := computeDomain([4]byte{1, 0, 0, 0}, common.HexToHash("0x6666666666666666666666666666666666666666666666666666666666666666"))
+	if d1 == d3 {
+		t.Error("computeDomain should differ across genesis validators roots")
+	}
+}
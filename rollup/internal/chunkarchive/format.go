@@ -0,0 +1,32 @@
+// Package chunkarchive serializes finalized chunks into self-describing, append-only flat
+// files: a leading meta record, one framed record per chunk, a trailing index of
+// (chunk_index -> file_offset), and a final checksum record covering the file. This lets a
+// cold-standby proposer bootstrap without re-syncing L2 from genesis, lets provers replay
+// deterministic regression fixtures, and lets operators move pre-proving chunk history off
+// the hot Postgres database.
+package chunkarchive
+
+import "errors"
+
+// magic identifies a chunk archive file and its format version.
+var magic = [8]byte{'S', 'C', 'R', 'C', 'K', 'A', 'R', '1'}
+
+// recordType identifies the kind of payload a framed record carries.
+type recordType uint16
+
+const (
+	recordTypeChunk    recordType = 1
+	recordTypeIndex    recordType = 2
+	recordTypeChecksum recordType = 3
+	recordTypeMeta     recordType = 4
+)
+
+// recordHeaderSize is the fixed-size framing header preceding every record's payload: a
+// 2-byte record type followed by an 8-byte little-endian payload length.
+const recordHeaderSize = 2 + 8
+
+// ErrBadMagic is returned when a file does not begin with the expected chunk archive magic.
+var ErrBadMagic = errors.New("chunkarchive: not a chunk archive file (bad magic)")
+
+// ErrChecksumMismatch is returned when a file's trailing checksum does not match its contents.
+var ErrChecksumMismatch = errors.New("chunkarchive: checksum mismatch, file is corrupt")
@@ -0,0 +1,110 @@
+package chunkarchive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ImportChunks reads every chunk record from the archive file at path and returns them in
+// file order, which is chunk-index order since ExportChunks writes them sequentially.
+func ImportChunks(path string) ([]*ArchiveChunk, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("chunkarchive: failed to open archive file: %w", err)
+	}
+	defer f.Close()
+
+	if err := checkMagic(f); err != nil {
+		return nil, err
+	}
+
+	var chunks []*ArchiveChunk
+	for {
+		typ, payload, err := readRecord(f)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("chunkarchive: failed to read record: %w", err)
+		}
+
+		switch typ {
+		case recordTypeChunk:
+			var archiveChunk ArchiveChunk
+			if err := json.Unmarshal(payload, &archiveChunk); err != nil {
+				return nil, fmt.Errorf("chunkarchive: failed to decode chunk record: %w", err)
+			}
+			chunks = append(chunks, &archiveChunk)
+		case recordTypeMeta, recordTypeIndex, recordTypeChecksum:
+			// Bookkeeping records, not part of the chunk data itself.
+		default:
+			return nil, fmt.Errorf("chunkarchive: unknown record type %d", typ)
+		}
+	}
+
+	return chunks, nil
+}
+
+// readIndex reads only the trailing (chunk_index -> file_offset) index record from the
+// archive file at path, without decoding every chunk record.
+func readIndex(path string) (map[uint64]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("chunkarchive: failed to open archive file: %w", err)
+	}
+	defer f.Close()
+
+	if err := checkMagic(f); err != nil {
+		return nil, err
+	}
+
+	for {
+		typ, payload, err := readRecord(f)
+		if err == io.EOF {
+			return nil, fmt.Errorf("chunkarchive: archive file has no index record")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("chunkarchive: failed to read record: %w", err)
+		}
+		if typ == recordTypeIndex {
+			return decodeIndex(payload)
+		}
+	}
+}
+
+// readMeta reads the "from" to use when re-deriving the first exported chunk's L1 block
+// range hash, as recorded by ExportChunks.
+func readMeta(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("chunkarchive: failed to open archive file: %w", err)
+	}
+	defer f.Close()
+
+	if err := checkMagic(f); err != nil {
+		return 0, err
+	}
+
+	typ, payload, err := readRecord(f)
+	if err != nil {
+		return 0, fmt.Errorf("chunkarchive: failed to read meta record: %w", err)
+	}
+	if typ != recordTypeMeta {
+		return 0, fmt.Errorf("chunkarchive: expected leading meta record, got type %d", typ)
+	}
+	return decodeMeta(payload)
+}
+
+func checkMagic(f *os.File) error {
+	got := make([]byte, len(magic))
+	if _, err := io.ReadFull(f, got); err != nil {
+		return fmt.Errorf("chunkarchive: failed to read magic: %w", err)
+	}
+	if !bytes.Equal(got, magic[:]) {
+		return ErrBadMagic
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package chunkarchive
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// writeRecord frames payload with a (type, length) header and writes it to w, returning the
+// total number of bytes written (header + payload) so callers can track file offsets.
+func writeRecord(w io.Writer, typ recordType, payload []byte) (uint64, error) {
+	header := make([]byte, recordHeaderSize)
+	binary.LittleEndian.PutUint16(header[0:2], uint16(typ))
+	binary.LittleEndian.PutUint64(header[2:10], uint64(len(payload)))
+
+	if _, err := w.Write(header); err != nil {
+		return 0, fmt.Errorf("failed to write record header: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return 0, fmt.Errorf("failed to write record payload: %w", err)
+	}
+	return uint64(len(header) + len(payload)), nil
+}
+
+// readRecord reads one framed (type, length, payload) record from r.
+func readRecord(r io.Reader) (recordType, []byte, error) {
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	typ := recordType(binary.LittleEndian.Uint16(header[0:2]))
+	length := binary.LittleEndian.Uint64(header[2:10])
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, fmt.Errorf("failed to read record payload: %w", err)
+	}
+	return typ, payload, nil
+}
+
+// encodeIndex serializes a chunk_index -> file_offset map as a sequence of (chunkIndex
+// uint64, fileOffset uint64) pairs, sorted by chunk index.
+func encodeIndex(index map[uint64]uint64) []byte {
+	chunkIndices := make([]uint64, 0, len(index))
+	for chunkIndex := range index {
+		chunkIndices = append(chunkIndices, chunkIndex)
+	}
+	sort.Slice(chunkIndices, func(i, j int) bool { return chunkIndices[i] < chunkIndices[j] })
+
+	buf := make([]byte, 0, len(index)*16)
+	for _, chunkIndex := range chunkIndices {
+		entry := make([]byte, 16)
+		binary.LittleEndian.PutUint64(entry[0:8], chunkIndex)
+		binary.LittleEndian.PutUint64(entry[8:16], index[chunkIndex])
+		buf = append(buf, entry...)
+	}
+	return buf
+}
+
+// decodeIndex parses a payload produced by encodeIndex into a chunk_index -> file_offset map.
+func decodeIndex(payload []byte) (map[uint64]uint64, error) {
+	if len(payload)%16 != 0 {
+		return nil, fmt.Errorf("malformed index: length %d is not a multiple of 16", len(payload))
+	}
+	index := make(map[uint64]uint64, len(payload)/16)
+	for i := 0; i < len(payload); i += 16 {
+		chunkIndex := binary.LittleEndian.Uint64(payload[i : i+8])
+		fileOffset := binary.LittleEndian.Uint64(payload[i+8 : i+16])
+		index[chunkIndex] = fileOffset
+	}
+	return index, nil
+}
+
+// encodeMeta serializes firstL1BlockRangeHashFrom, the "from" to use when re-deriving the
+// first exported chunk's L1 block range hash (see ArchiveMeta).
+func encodeMeta(firstL1BlockRangeHashFrom uint64) []byte {
+	payload := make([]byte, 8)
+	binary.LittleEndian.PutUint64(payload, firstL1BlockRangeHashFrom)
+	return payload
+}
+
+// decodeMeta parses a payload produced by encodeMeta.
+func decodeMeta(payload []byte) (uint64, error) {
+	if len(payload) != 8 {
+		return 0, fmt.Errorf("malformed meta record: length %d, want 8", len(payload))
+	}
+	return binary.LittleEndian.Uint64(payload), nil
+}
@@ -0,0 +1,90 @@
+package chunkarchive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestWriteReadRecordRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	payload := []byte("hello chunk archive")
+
+	n, err := writeRecord(&buf, recordTypeChunk, payload)
+	if err != nil {
+		t.Fatalf("writeRecord: %v", err)
+	}
+	if n != uint64(recordHeaderSize+len(payload)) {
+		t.Errorf("writeRecord returned %d bytes written, want %d", n, recordHeaderSize+len(payload))
+	}
+
+	typ, got, err := readRecord(&buf)
+	if err != nil {
+		t.Fatalf("readRecord: %v", err)
+	}
+	if typ != recordTypeChunk {
+		t.Errorf("readRecord type = %d, want %d", typ, recordTypeChunk)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("readRecord payload = %q, want %q", got, payload)
+	}
+}
+
+func TestReadRecordTruncatedPayload(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := writeRecord(&buf, recordTypeChunk, []byte("full payload")); err != nil {
+		t.Fatalf("writeRecord: %v", err)
+	}
+	truncated := buf.Bytes()[:recordHeaderSize+3]
+
+	if _, _, err := readRecord(bytes.NewReader(truncated)); err == nil {
+		t.Fatal("expected an error reading a record whose payload was cut short")
+	}
+}
+
+func TestEncodeDecodeIndexRoundTrip(t *testing.T) {
+	index := map[uint64]uint64{5: 100, 1: 8, 3: 54}
+
+	decoded, err := decodeIndex(encodeIndex(index))
+	if err != nil {
+		t.Fatalf("decodeIndex: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, index) {
+		t.Errorf("decodeIndex(encodeIndex(index)) = %v, want %v", decoded, index)
+	}
+}
+
+func TestEncodeIndexIsSortedByChunkIndex(t *testing.T) {
+	payload := encodeIndex(map[uint64]uint64{5: 0, 1: 0, 3: 0})
+	var order []uint64
+	for i := 0; i < len(payload); i += 16 {
+		order = append(order, binary.LittleEndian.Uint64(payload[i:i+8]))
+	}
+	want := []uint64{1, 3, 5}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("encodeIndex chunk index order = %v, want %v", order, want)
+	}
+}
+
+func TestDecodeIndexRejectsMalformedLength(t *testing.T) {
+	if _, err := decodeIndex([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected an error decoding a payload whose length isn't a multiple of 16")
+	}
+}
+
+func TestEncodeDecodeMetaRoundTrip(t *testing.T) {
+	decoded, err := decodeMeta(encodeMeta(424242))
+	if err != nil {
+		t.Fatalf("decodeMeta: %v", err)
+	}
+	if decoded != 424242 {
+		t.Errorf("decodeMeta(encodeMeta(424242)) = %d, want 424242", decoded)
+	}
+}
+
+func TestDecodeMetaRejectsMalformedLength(t *testing.T) {
+	if _, err := decodeMeta([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected an error decoding a meta payload that isn't 8 bytes")
+	}
+}
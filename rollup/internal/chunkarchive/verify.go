@@ -0,0 +1,189 @@
+package chunkarchive
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+
+	bridgeAbi "scroll-tech/rollup/abi"
+	"scroll-tech/rollup/internal/controller/watcher"
+	"scroll-tech/rollup/internal/l1client"
+)
+
+// checksumRecordSize is the fixed on-disk size of the trailing checksum record: its header
+// plus a sha256 digest.
+const checksumRecordSize = recordHeaderSize + sha256.Size
+
+// L1BlockRangeHasher re-derives the canonical L1 block range hash for [from, to]. A live
+// watcher.ChunkProposer satisfies this, letting verification reuse the exact source of
+// truth used when chunks were originally proposed. OracleHasher satisfies it too, for
+// callers (such as the chunk-archive CLI) that only need the L1 view oracle lookup and
+// not a full ChunkProposer.
+type L1BlockRangeHasher interface {
+	GetL1BlockRangeHash(ctx context.Context, from, to uint64) (*common.Hash, error)
+}
+
+// OracleHasher re-derives L1 block range hashes directly from the L1 view oracle contract,
+// the same call ChunkProposer.GetL1BlockRangeHash makes, without needing a full
+// ChunkProposer (and the config/db/beacon-light dependencies that come with one).
+type OracleHasher struct {
+	l1Client   *l1client.Client
+	oracleAddr common.Address
+	oracleABI  *abi.ABI
+}
+
+// NewOracleHasher builds an OracleHasher that queries the L1 view oracle contract at
+// oracleAddr through l1Client.
+func NewOracleHasher(l1Client *l1client.Client, oracleAddr common.Address) *OracleHasher {
+	return &OracleHasher{l1Client: l1Client, oracleAddr: oracleAddr, oracleABI: bridgeAbi.L1ViewOracleABI}
+}
+
+// GetL1BlockRangeHash gets the l1 block range hash from the l1 view oracle smart contract.
+func (h *OracleHasher) GetL1BlockRangeHash(ctx context.Context, from, to uint64) (*common.Hash, error) {
+	input, err := h.oracleABI.Pack("blockRangeHash", big.NewInt(int64(from)), big.NewInt(int64(to)))
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := h.l1Client.CallContract(ctx, ethereum.CallMsg{To: &h.oracleAddr, Data: input}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.oracleABI.Unpack("blockRangeHash", output)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := result[0].([32]byte)
+	if !ok {
+		return nil, fmt.Errorf("chunkarchive: could not cast block range hash to [32]byte")
+	}
+
+	hash := common.Hash(b)
+	return &hash, nil
+}
+
+// RecomputeRowConsumption recomputes an archived chunk's per-sub-circuit row consumption
+// from its stored blocks and compares the maximum against the value stored alongside it,
+// returning the recomputed figures and an error if they diverge.
+func RecomputeRowConsumption(archiveChunk *ArchiveChunk) (map[string]uint64, uint64, error) {
+	rowConsumption, max, err := watcher.ComputeChunkRowConsumption(archiveChunk.Blocks)
+	if err != nil {
+		return nil, 0, fmt.Errorf("chunkarchive: failed to recompute row consumption for chunk %d: %w", archiveChunk.ChunkIndex, err)
+	}
+	if storedMax := maxOf(archiveChunk.RowConsumption); max != storedMax {
+		return nil, 0, fmt.Errorf("chunkarchive: chunk %d row consumption mismatch: stored max %d, recomputed %d", archiveChunk.ChunkIndex, storedMax, max)
+	}
+	return rowConsumption, max, nil
+}
+
+// VerifyChecksum confirms that the archive file at path has not been truncated or
+// corrupted since it was written, by recomputing its trailing checksum.
+func VerifyChecksum(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("chunkarchive: failed to open archive file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("chunkarchive: failed to stat archive file: %w", err)
+	}
+	if info.Size() < int64(len(magic)+checksumRecordSize) {
+		return fmt.Errorf("chunkarchive: archive file is too short to be valid")
+	}
+
+	h := sha256.New()
+	if _, err := io.CopyN(h, f, info.Size()-checksumRecordSize); err != nil {
+		return fmt.Errorf("chunkarchive: failed to hash archive body: %w", err)
+	}
+
+	typ, payload, err := readRecord(f)
+	if err != nil {
+		return fmt.Errorf("chunkarchive: failed to read checksum record: %w", err)
+	}
+	if typ != recordTypeChecksum {
+		return fmt.Errorf("chunkarchive: expected trailing checksum record, got type %d", typ)
+	}
+	if !bytes.Equal(h.Sum(nil), payload) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+// Verify performs a full integrity check of the archive file at path: the trailing
+// checksum, that the index has exactly one entry per chunk record, each chunk's row
+// consumption, and each chunk's L1 block range hash (re-derived via hasher). The file
+// offsets stored in the index are not checked against the records' positions.
+func Verify(ctx context.Context, path string, hasher L1BlockRangeHasher) error {
+	if err := VerifyChecksum(path); err != nil {
+		return err
+	}
+
+	index, err := readIndex(path)
+	if err != nil {
+		return err
+	}
+
+	chunks, err := ImportChunks(path)
+	if err != nil {
+		return err
+	}
+	if len(index) != len(chunks) {
+		return fmt.Errorf("chunkarchive: index has %d entries but archive has %d chunk records", len(index), len(chunks))
+	}
+
+	firstL1BlockRangeHashFrom, err := readMeta(path)
+	if err != nil {
+		return err
+	}
+
+	var prevLastAppliedL1Block uint64
+	for i, archiveChunk := range chunks {
+		if _, ok := index[archiveChunk.ChunkIndex]; !ok {
+			return fmt.Errorf("chunkarchive: chunk %d is missing from the trailing index", archiveChunk.ChunkIndex)
+		}
+
+		if _, _, err := RecomputeRowConsumption(archiveChunk); err != nil {
+			return err
+		}
+
+		l1BlockRangeHashFrom := firstL1BlockRangeHashFrom
+		if i > 0 {
+			l1BlockRangeHashFrom = prevLastAppliedL1Block
+			if l1BlockRangeHashFrom != 0 {
+				l1BlockRangeHashFrom++
+			}
+		}
+		recomputedHash, err := hasher.GetL1BlockRangeHash(ctx, l1BlockRangeHashFrom, archiveChunk.LastAppliedL1Block)
+		if err != nil {
+			return fmt.Errorf("chunkarchive: failed to re-derive l1 block range hash for chunk %d: %w", archiveChunk.ChunkIndex, err)
+		}
+		if *recomputedHash != archiveChunk.L1BlockRangeHash {
+			return fmt.Errorf("chunkarchive: chunk %d l1 block range hash mismatch: stored %s, recomputed %s", archiveChunk.ChunkIndex, archiveChunk.L1BlockRangeHash, recomputedHash)
+		}
+
+		prevLastAppliedL1Block = archiveChunk.LastAppliedL1Block
+	}
+
+	return nil
+}
+
+func maxOf(rowConsumption map[string]uint64) uint64 {
+	var max uint64
+	for _, v := range rowConsumption {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
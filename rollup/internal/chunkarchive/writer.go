@@ -0,0 +1,133 @@
+package chunkarchive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/scroll-tech/go-ethereum/common"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/controller/watcher"
+	"scroll-tech/rollup/internal/orm"
+)
+
+// ArchiveChunk is the self-describing, serialized form of a single finalized chunk: its
+// blocks, the row consumption they accumulate to, and the L1 bookkeeping needed to verify
+// it against the chain without re-deriving it from scratch.
+type ArchiveChunk struct {
+	ChunkIndex         uint64                `json:"chunkIndex"`
+	StartBlockNumber   uint64                `json:"startBlockNumber"`
+	EndBlockNumber     uint64                `json:"endBlockNumber"`
+	Hash               common.Hash           `json:"hash"`
+	ParentChunkHash    common.Hash           `json:"parentChunkHash"`
+	LastAppliedL1Block uint64                `json:"lastAppliedL1Block"`
+	L1BlockRangeHash   common.Hash           `json:"l1BlockRangeHash"`
+	RowConsumption     map[string]uint64     `json:"rowConsumption"`
+	Blocks             []*types.WrappedBlock `json:"blocks"`
+}
+
+// ExportChunks serializes finalized chunks with index in [fromIndex, toIndex] (inclusive)
+// into a self-describing, append-only archive file at path: one framed record per chunk, a
+// trailing index of (chunk_index -> file_offset), and a final checksum record covering the
+// whole file.
+//
+// GetChunksByIndexRange is a bulk-by-index read this feature adds to orm.Chunk alongside
+// the existing single-chunk lookups (GetLatestChunk); it must land together with this file.
+func ExportChunks(ctx context.Context, chunkOrm *orm.Chunk, l2BlockOrm *orm.L2Block, fromIndex, toIndex uint64, path string) error {
+	chunks, err := chunkOrm.GetChunksByIndexRange(ctx, fromIndex, toIndex)
+	if err != nil {
+		return fmt.Errorf("chunkarchive: failed to load chunks [%d, %d]: %w", fromIndex, toIndex, err)
+	}
+
+	// The "from" for re-deriving the first exported chunk's L1 block range hash is the
+	// predecessor chunk's LastAppliedL1Block (+1, same off-by-one ChunkProposer.proposeChunk
+	// applies), not 0 - 0 is only correct when the export starts at chunk index 0. Since
+	// Verify has no other way to learn that predecessor once chunks before fromIndex aren't
+	// in the archive, it's recorded in a dedicated meta record below.
+	var firstL1BlockRangeHashFrom uint64
+	if fromIndex > 0 {
+		predecessors, err := chunkOrm.GetChunksByIndexRange(ctx, fromIndex-1, fromIndex-1)
+		if err != nil || len(predecessors) != 1 {
+			return fmt.Errorf("chunkarchive: failed to load predecessor of chunk %d: %w", fromIndex, err)
+		}
+		firstL1BlockRangeHashFrom = predecessors[0].LastAppliedL1Block
+		if firstL1BlockRangeHashFrom != 0 {
+			firstL1BlockRangeHashFrom++
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("chunkarchive: failed to create archive file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	w := io.MultiWriter(f, h)
+
+	if _, err := w.Write(magic[:]); err != nil {
+		return fmt.Errorf("chunkarchive: failed to write magic: %w", err)
+	}
+	metaOffset, err := writeRecord(w, recordTypeMeta, encodeMeta(firstL1BlockRangeHashFrom))
+	if err != nil {
+		return fmt.Errorf("chunkarchive: failed to write meta record: %w", err)
+	}
+
+	index := make(map[uint64]uint64, len(chunks))
+	offset := uint64(len(magic)) + metaOffset
+
+	for _, dbChunk := range chunks {
+		blockCount := int(dbChunk.EndBlockNumber-dbChunk.StartBlockNumber) + 1
+		blocks, err := l2BlockOrm.GetL2WrappedBlocksGEHeight(ctx, dbChunk.StartBlockNumber, blockCount)
+		if err != nil {
+			return fmt.Errorf("chunkarchive: failed to load blocks for chunk %d: %w", dbChunk.Index, err)
+		}
+		if len(blocks) != blockCount {
+			return fmt.Errorf("chunkarchive: chunk %d expects %d blocks from height %d, got %d", dbChunk.Index, blockCount, dbChunk.StartBlockNumber, len(blocks))
+		}
+
+		rowConsumption, _, err := watcher.ComputeChunkRowConsumption(blocks)
+		if err != nil {
+			return fmt.Errorf("chunkarchive: failed to compute row consumption for chunk %d: %w", dbChunk.Index, err)
+		}
+
+		payload, err := json.Marshal(ArchiveChunk{
+			ChunkIndex:         dbChunk.Index,
+			StartBlockNumber:   dbChunk.StartBlockNumber,
+			EndBlockNumber:     dbChunk.EndBlockNumber,
+			Hash:               common.HexToHash(dbChunk.Hash),
+			ParentChunkHash:    common.HexToHash(dbChunk.ParentChunkHash),
+			LastAppliedL1Block: dbChunk.LastAppliedL1Block,
+			L1BlockRangeHash:   common.HexToHash(dbChunk.L1BlockRangeHash),
+			RowConsumption:     rowConsumption,
+			Blocks:             blocks,
+		})
+		if err != nil {
+			return fmt.Errorf("chunkarchive: failed to encode chunk %d: %w", dbChunk.Index, err)
+		}
+
+		index[dbChunk.Index] = offset
+		n, err := writeRecord(w, recordTypeChunk, payload)
+		if err != nil {
+			return fmt.Errorf("chunkarchive: failed to write chunk %d: %w", dbChunk.Index, err)
+		}
+		offset += n
+	}
+
+	if _, err := writeRecord(w, recordTypeIndex, encodeIndex(index)); err != nil {
+		return fmt.Errorf("chunkarchive: failed to write index: %w", err)
+	}
+
+	// The checksum record itself is written directly to f, not w, so that it covers
+	// everything preceding it without covering itself.
+	if _, err := writeRecord(f, recordTypeChecksum, h.Sum(nil)); err != nil {
+		return fmt.Errorf("chunkarchive: failed to write checksum: %w", err)
+	}
+
+	return nil
+}
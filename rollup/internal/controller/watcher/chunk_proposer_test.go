@@ -0,0 +1,136 @@
+package watcher
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/crypto"
+
+	"scroll-tech/rollup/internal/beaconlight"
+)
+
+// fakeExecHeaderVerifier reports every blockNumber in checkpoints as beacon-light
+// authenticated with the given hash; any other block number has no checkpoint yet.
+type fakeExecHeaderVerifier struct {
+	checkpoints map[uint64]common.Hash
+}
+
+func (f *fakeExecHeaderVerifier) VerifyExecHeader(blockNumber uint64, execBlockHash common.Hash) error {
+	authenticated, ok := f.checkpoints[blockNumber]
+	if !ok {
+		return beaconlight.ErrNoFinalizedHeader
+	}
+	if authenticated != execBlockHash {
+		return errors.New("beaconlight: execution block hash mismatch")
+	}
+	return nil
+}
+
+func blockHashesRange(from, to uint64) map[uint64]common.Hash {
+	hashes := make(map[uint64]common.Hash, to-from+1)
+	for n := from; n <= to; n++ {
+		hashes[n] = common.BigToHash(new(big.Int).SetUint64(n))
+	}
+	return hashes
+}
+
+// parentHashesFor derives the parentHashes map a genuine, internally-consistent header
+// chain would produce for blockHashes: each block's parent hash is simply its predecessor's
+// hash.
+func parentHashesFor(blockHashes map[uint64]common.Hash, from, to uint64) map[uint64]common.Hash {
+	parents := make(map[uint64]common.Hash, to-from+1)
+	for n := from + 1; n <= to; n++ {
+		parents[n] = blockHashes[n-1]
+	}
+	return parents
+}
+
+func TestBlobGasForCalldataSize(t *testing.T) {
+	tests := []struct {
+		calldataSize uint64
+		want         uint64
+	}{
+		{0, 0},
+		{1, 1}, // smallest possible chunk rounds up to 1 gas
+		{usableBytesPerBlob, blobGasPerBlob},          // exactly one full blob
+		{usableBytesPerBlob + 1, blobGasPerBlob + 1}, // one byte into a second blob
+	}
+
+	for _, tt := range tests {
+		if got := blobGasForCalldataSize(tt.calldataSize); got != tt.want {
+			t.Errorf("blobGasForCalldataSize(%d) = %d, want %d", tt.calldataSize, got, tt.want)
+		}
+	}
+}
+
+func TestCheckBlockRangeAgainstBeaconLightNoCheckpointAtEndpoint(t *testing.T) {
+	blockHashes := blockHashesRange(10, 20)
+	parentHashes := parentHashesFor(blockHashes, 10, 20)
+	// A checkpoint exists, but not at the range's upper endpoint (20) - no longer
+	// sufficient, since blocks past a mid-range checkpoint are only RPC-trusted, not
+	// beacon-light authenticated (see checkBlockRangeAgainstBeaconLight's doc comment).
+	verifier := &fakeExecHeaderVerifier{checkpoints: map[uint64]common.Hash{15: blockHashes[15]}}
+
+	err := checkBlockRangeAgainstBeaconLight(verifier, blockHashes, parentHashes, 10, 20, common.Hash{})
+	if err == nil {
+		t.Fatal("expected an error when the range's upper endpoint has no beacon light checkpoint")
+	}
+}
+
+func TestCheckBlockRangeAgainstBeaconLightEndpointCheckpointIsEnough(t *testing.T) {
+	blockHashes := blockHashesRange(10, 20)
+	parentHashes := parentHashesFor(blockHashes, 10, 20)
+	// Only the range's upper endpoint is checkpointed; every other block is authenticated
+	// transitively via the parent-hash chain, not its own checkpoint.
+	verifier := &fakeExecHeaderVerifier{checkpoints: map[uint64]common.Hash{20: blockHashes[20]}}
+
+	oracleHash := recomputeRangeHash(blockHashes, 10, 20)
+	if err := checkBlockRangeAgainstBeaconLight(verifier, blockHashes, parentHashes, 10, 20, oracleHash); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckBlockRangeAgainstBeaconLightEndpointMismatchAborts(t *testing.T) {
+	blockHashes := blockHashesRange(10, 20)
+	parentHashes := parentHashesFor(blockHashes, 10, 20)
+	verifier := &fakeExecHeaderVerifier{checkpoints: map[uint64]common.Hash{20: common.Hash{1}}}
+
+	oracleHash := recomputeRangeHash(blockHashes, 10, 20)
+	if err := checkBlockRangeAgainstBeaconLight(verifier, blockHashes, parentHashes, 10, 20, oracleHash); err == nil {
+		t.Fatal("expected an error when the endpoint's checkpointed hash diverges")
+	}
+}
+
+func TestCheckBlockRangeAgainstBeaconLightBrokenChainRejected(t *testing.T) {
+	blockHashes := blockHashesRange(10, 20)
+	parentHashes := parentHashesFor(blockHashes, 10, 20)
+	// Tamper with one interior parent hash reference, simulating an RPC that serves a
+	// genuinely checkpointed endpoint but forges (or omits) part of the chain beneath it.
+	parentHashes[15] = common.Hash{0xde, 0xad}
+	verifier := &fakeExecHeaderVerifier{checkpoints: map[uint64]common.Hash{20: blockHashes[20]}}
+
+	oracleHash := recomputeRangeHash(blockHashes, 10, 20)
+	if err := checkBlockRangeAgainstBeaconLight(verifier, blockHashes, parentHashes, 10, 20, oracleHash); err == nil {
+		t.Fatal("expected an error when the header chain beneath the checkpoint is broken")
+	}
+}
+
+func TestCheckBlockRangeAgainstBeaconLightOracleMismatch(t *testing.T) {
+	blockHashes := blockHashesRange(10, 20)
+	parentHashes := parentHashesFor(blockHashes, 10, 20)
+	verifier := &fakeExecHeaderVerifier{checkpoints: map[uint64]common.Hash{20: blockHashes[20]}}
+
+	if err := checkBlockRangeAgainstBeaconLight(verifier, blockHashes, parentHashes, 10, 20, common.Hash{0xff}); err == nil {
+		t.Fatal("expected an error when the recomputed range hash doesn't match the oracle's")
+	}
+}
+
+func recomputeRangeHash(blockHashes map[uint64]common.Hash, from, to uint64) common.Hash {
+	var concatenated []byte
+	for n := from; n <= to; n++ {
+		concatenated = append(concatenated, blockHashes[n].Bytes()...)
+	}
+	return crypto.Keccak256Hash(concatenated)
+}
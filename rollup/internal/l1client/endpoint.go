@@ -0,0 +1,92 @@
+package l1client
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+)
+
+// endpoint wraps a single dialed L1 RPC connection together with its health state.
+type endpoint struct {
+	url    string
+	client *ethclient.Client
+
+	mu               sync.Mutex
+	healthy          bool
+	consecutiveFails int
+	backoff          time.Duration
+	nextProbeAt      time.Time
+}
+
+// recordResult updates the endpoint's health based on the outcome of its most recent call.
+// Only transient errors (timeouts, connection failures) count against an endpoint's health;
+// severe errors (e.g. reverts, bad requests) are legitimate responses and do not.
+func (e *endpoint) recordResult(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err != nil && classifyErr(err) == errKindTransient {
+		e.consecutiveFails++
+		if e.consecutiveFails >= unhealthyThreshold {
+			if e.backoff == 0 {
+				e.backoff = defaultProbeBackoff
+			} else if e.backoff < maxProbeBackoff {
+				e.backoff *= 2
+				if e.backoff > maxProbeBackoff {
+					e.backoff = maxProbeBackoff
+				}
+			}
+			if e.healthy {
+				log.Warn("l1 rpc endpoint marked unhealthy", "url", e.url, "consecutiveFails", e.consecutiveFails)
+			}
+			e.healthy = false
+			e.nextProbeAt = time.Now().Add(e.backoff)
+		}
+		return
+	}
+
+	if !e.healthy {
+		log.Info("l1 rpc endpoint recovered", "url", e.url)
+	}
+	e.healthy = true
+	e.consecutiveFails = 0
+	e.backoff = 0
+}
+
+type errKind int
+
+const (
+	errKindTransient errKind = iota
+	errKindSevere
+)
+
+// classifyErr distinguishes transient errors (timeouts, connection issues) that reflect
+// a problem with the endpoint itself from severe errors (reverts, bad requests) that are
+// legitimate protocol-level responses and should not count against endpoint health.
+func classifyErr(err error) errKind {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "timeout"),
+		strings.Contains(msg, "timed out"),
+		strings.Contains(msg, "connection"),
+		strings.Contains(msg, "eof"),
+		strings.Contains(msg, "refused"),
+		strings.Contains(msg, "reset by peer"),
+		strings.Contains(msg, "no such host"),
+		strings.Contains(msg, "502"),
+		strings.Contains(msg, "503"),
+		strings.Contains(msg, "504"):
+		return errKindTransient
+	case strings.Contains(msg, "execution reverted"),
+		strings.Contains(msg, "revert"),
+		strings.Contains(msg, "invalid"),
+		strings.Contains(msg, "bad request"),
+		strings.Contains(msg, "400"):
+		return errKindSevere
+	default:
+		return errKindTransient
+	}
+}
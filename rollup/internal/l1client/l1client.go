@@ -0,0 +1,276 @@
+// Package l1client provides a quorum-backed, multi-endpoint L1 RPC client.
+//
+// It fans reads out across N configured RPC endpoints and requires a quorum
+// of matching responses before returning, so a single misbehaving or
+// unreachable provider cannot become a single point of failure for the
+// chunk proposer.
+package l1client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+)
+
+const (
+	// unhealthyThreshold is the number of consecutive transient failures after which an endpoint is marked unhealthy.
+	unhealthyThreshold = 3
+	// defaultProbeBackoff is the initial backoff applied before re-probing a newly unhealthy endpoint.
+	defaultProbeBackoff = 5 * time.Second
+	// maxProbeBackoff caps the exponential backoff applied to a repeatedly failing endpoint.
+	maxProbeBackoff = 5 * time.Minute
+)
+
+// ErrNoQuorum is returned when no subset of responding endpoints reaches the configured quorum.
+var ErrNoQuorum = errors.New("l1client: no quorum reached among configured endpoints")
+
+// Config configures a quorum-backed multi-endpoint Client.
+type Config struct {
+	// Endpoints are the L1 RPC URLs to fan reads out across.
+	Endpoints []string
+	// Quorum is the minimum number of matching responses required before a read is accepted.
+	Quorum int
+}
+
+// Client fans reads out across a set of L1 RPC endpoints and requires a quorum of matching
+// responses before returning, tracking per-endpoint health along the way.
+type Client struct {
+	endpoints []*endpoint
+	quorum    int
+
+	mu      sync.Mutex
+	nextIdx int
+
+	contradictionTotal prometheus.Counter
+}
+
+// New dials every configured endpoint and returns a quorum-backed Client.
+func New(cfg Config, reg prometheus.Registerer) (*Client, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, errors.New("l1client: at least one endpoint is required")
+	}
+	if cfg.Quorum <= 0 || cfg.Quorum > len(cfg.Endpoints) {
+		return nil, fmt.Errorf("l1client: quorum %d is invalid for %d endpoint(s)", cfg.Quorum, len(cfg.Endpoints))
+	}
+
+	endpoints := make([]*endpoint, 0, len(cfg.Endpoints))
+	for _, url := range cfg.Endpoints {
+		c, err := ethclient.Dial(url)
+		if err != nil {
+			return nil, fmt.Errorf("l1client: failed to dial endpoint %s: %w", url, err)
+		}
+		endpoints = append(endpoints, &endpoint{url: url, client: c, healthy: true})
+	}
+
+	return &Client{
+		endpoints: endpoints,
+		quorum:    cfg.Quorum,
+		contradictionTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "l1_rpc_contradiction_total",
+			Help: "Total number of times configured L1 RPC endpoints returned divergent responses for the same call.",
+		}),
+	}, nil
+}
+
+// CallContract executes a contract call against a quorum of healthy endpoints.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c.quorumCall(func(e *endpoint) ([]byte, error) {
+		return e.client.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+// CodeAt fetches contract code from a quorum of healthy endpoints.
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.quorumCall(func(e *endpoint) ([]byte, error) {
+		return e.client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// HeaderByNumber fetches an L1 block header from a quorum of healthy endpoints, voting
+// on the reported block hash so a single divergent endpoint cannot poison the result.
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error) {
+	var headersMu sync.Mutex
+	headers := make(map[common.Hash]*gethTypes.Header)
+	hash, err := c.quorumCall(func(e *endpoint) ([]byte, error) {
+		header, err := e.client.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		h := header.Hash()
+		headersMu.Lock()
+		headers[h] = header
+		headersMu.Unlock()
+		return h.Bytes(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return headers[common.BytesToHash(hash)], nil
+}
+
+type callResult struct {
+	value []byte
+	err   error
+}
+
+// quorumCall round-robins through usableEndpoints, starting with exactly c.quorum of them
+// (the cheapest batch that could possibly reach quorum) and only contacting more, one at a
+// time, if that batch didn't agree. In the common case where the first c.quorum endpoints
+// agree, this costs c.quorum RPC calls per read, not one per configured endpoint - that's
+// the cost/load-spreading point of using a quorum of redundant providers instead of always
+// fanning out to all of them.
+func (c *Client) quorumCall(call func(*endpoint) ([]byte, error)) ([]byte, error) {
+	eps := c.usableEndpoints()
+	if len(eps) == 0 {
+		return nil, errors.New("l1client: no healthy endpoints available")
+	}
+
+	var results []callResult
+	var value []byte
+	var contradiction bool
+	var err error
+	for contacted := 0; contacted < len(eps); {
+		batch := c.quorum
+		if contacted > 0 {
+			batch = 1
+		}
+		if contacted+batch > len(eps) {
+			batch = len(eps) - contacted
+		}
+
+		batchResults := c.callBatch(eps[contacted:contacted+batch], call)
+		results = append(results, batchResults...)
+		contacted += batch
+
+		value, contradiction, err = tallyQuorum(results, c.quorum)
+		if !errors.Is(err, ErrNoQuorum) {
+			break
+		}
+	}
+
+	if contradiction {
+		log.Warn("l1 rpc endpoints returned divergent responses")
+		c.contradictionTotal.Inc()
+	}
+	return value, err
+}
+
+// callBatch calls every endpoint in eps concurrently and returns their results in order.
+func (c *Client) callBatch(eps []*endpoint, call func(*endpoint) ([]byte, error)) []callResult {
+	results := make([]callResult, len(eps))
+	var wg sync.WaitGroup
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			value, err := call(ep)
+			ep.recordResult(err)
+			results[i] = callResult{value: value, err: err}
+		}(i, ep)
+	}
+	wg.Wait()
+	return results
+}
+
+// tallyQuorum votes on the endpoint results of a single quorumCall round and returns the
+// value agreed on by at least quorum of them. Transient errors don't count as a vote
+// either way, since the endpoint simply didn't answer; severe errors vote using
+// severeErrKey so that the same revert reported in different words by different
+// providers still reaches quorum. contradiction reports whether more than one response
+// came back but they didn't all agree, independent of whether quorum was reached.
+func tallyQuorum(results []callResult, quorum int) (value []byte, contradiction bool, err error) {
+	votes := make(map[string]int)
+	values := make(map[string][]byte)
+	errsByKey := make(map[string]error)
+	responded := 0
+	for _, r := range results {
+		if r.err != nil {
+			if classifyErr(r.err) == errKindTransient {
+				continue
+			}
+			key := severeErrKey(r.err)
+			votes[key]++
+			errsByKey[key] = r.err
+			responded++
+			continue
+		}
+		key := string(r.value)
+		votes[key]++
+		values[key] = r.value
+		responded++
+	}
+
+	var bestKey string
+	var bestCount int
+	for k, n := range votes {
+		if n > bestCount {
+			bestCount = n
+			bestKey = k
+		}
+	}
+
+	contradiction = responded > 1 && bestCount < responded
+	if bestCount < quorum {
+		return nil, contradiction, fmt.Errorf("%w: best agreement %d/%d responses, need %d", ErrNoQuorum, bestCount, responded, quorum)
+	}
+	if resultErr, ok := errsByKey[bestKey]; ok {
+		return nil, contradiction, resultErr
+	}
+	return values[bestKey], contradiction, nil
+}
+
+// revertDataErr is implemented by JSON-RPC errors that carry structured revert data (e.g.
+// go-ethereum's rpc.DataError). That data is the same across providers even when their
+// human-readable error messages are formatted differently.
+type revertDataErr interface {
+	ErrorData() interface{}
+}
+
+// severeErrKey derives the vote key for a severe (non-transient) error, normalizing
+// provider-specific message formatting so that a revert every endpoint agrees on doesn't
+// fracture quorum just because the providers worded it differently. Errors that carry
+// structured revert data are keyed on that data; everything else falls back to a
+// lowercased, trimmed message.
+func severeErrKey(err error) string {
+	if de, ok := err.(revertDataErr); ok {
+		if data := de.ErrorData(); data != nil {
+			return fmt.Sprintf("err-data:%v", data)
+		}
+	}
+	return "err:" + strings.ToLower(strings.TrimSpace(err.Error()))
+}
+
+// usableEndpoints returns endpoints that are healthy, or unhealthy endpoints whose backoff
+// has elapsed and are due for a probe, ordered starting from a rotating offset so that
+// quorumCall's initial round-robin batch doesn't always land on the same endpoints.
+func (c *Client) usableEndpoints() []*endpoint {
+	c.mu.Lock()
+	start := c.nextIdx
+	c.nextIdx = (c.nextIdx + 1) % len(c.endpoints)
+	c.mu.Unlock()
+
+	now := time.Now()
+	usable := make([]*endpoint, 0, len(c.endpoints))
+	for i := 0; i < len(c.endpoints); i++ {
+		ep := c.endpoints[(start+i)%len(c.endpoints)]
+		ep.mu.Lock()
+		ok := ep.healthy || now.After(ep.nextProbeAt)
+		ep.mu.Unlock()
+		if ok {
+			usable = append(usable, ep)
+		}
+	}
+	return usable
+}
@@ -0,0 +1,172 @@
+package l1client
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newTestClient builds a Client with n bare endpoints, suitable for exercising quorumCall
+// without dialing real RPC connections (the passed call func never touches ep.client).
+func newTestClient(n, quorum int) *Client {
+	endpoints := make([]*endpoint, n)
+	for i := range endpoints {
+		endpoints[i] = &endpoint{url: string(rune('a' + i)), healthy: true}
+	}
+	return &Client{
+		endpoints:          endpoints,
+		quorum:             quorum,
+		contradictionTotal: prometheus.NewCounter(prometheus.CounterOpts{Name: "test_l1_rpc_contradiction_total"}),
+	}
+}
+
+func TestQuorumCallOnlyContactsQuorumWhenEndpointsAgree(t *testing.T) {
+	c := newTestClient(5, 2)
+
+	var mu sync.Mutex
+	contacted := 0
+	value, err := c.quorumCall(func(ep *endpoint) ([]byte, error) {
+		mu.Lock()
+		contacted++
+		mu.Unlock()
+		return []byte("agreed"), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(value) != "agreed" {
+		t.Errorf("value = %q, want %q", value, "agreed")
+	}
+	if contacted != 2 {
+		t.Errorf("contacted %d endpoints, want exactly quorum (2) when they all agree", contacted)
+	}
+}
+
+func TestQuorumCallEscalatesOneAtATimeOnDisagreement(t *testing.T) {
+	c := newTestClient(5, 2)
+
+	var mu sync.Mutex
+	contacted := 0
+	value, err := c.quorumCall(func(ep *endpoint) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		contacted++
+		// The first endpoint contacted disagrees with everyone else, so quorum can't be
+		// reached from the initial 2-endpoint batch alone and must escalate.
+		if contacted == 1 {
+			return []byte("odd one out"), nil
+		}
+		return []byte("agreed"), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(value) != "agreed" {
+		t.Errorf("value = %q, want %q", value, "agreed")
+	}
+	if contacted != 3 {
+		t.Errorf("contacted %d endpoints, want 3 (2 initial + 1 escalation) before quorum was reached", contacted)
+	}
+}
+
+func TestQuorumCallFailsWhenEndpointsExhausted(t *testing.T) {
+	c := newTestClient(3, 2)
+
+	contacted := 0
+	var mu sync.Mutex
+	_, err := c.quorumCall(func(ep *endpoint) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		contacted++
+		return []byte(ep.url), nil // every endpoint disagrees with every other
+	})
+	if !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("err = %v, want ErrNoQuorum once every usable endpoint disagreed", err)
+	}
+	if contacted != 3 {
+		t.Errorf("contacted %d endpoints, want all 3 usable endpoints tried before giving up", contacted)
+	}
+}
+
+func TestTallyQuorumSingleEndpoint(t *testing.T) {
+	results := []callResult{{value: []byte("a")}}
+	value, contradiction, err := tallyQuorum(results, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contradiction {
+		t.Error("a single response should never be a contradiction")
+	}
+	if string(value) != "a" {
+		t.Errorf("value = %q, want %q", value, "a")
+	}
+}
+
+func TestTallyQuorumAllTransientFailures(t *testing.T) {
+	results := []callResult{
+		{err: errors.New("dial tcp: connection refused")},
+		{err: errors.New("context deadline exceeded: timeout")},
+	}
+	_, contradiction, err := tallyQuorum(results, 1)
+	if !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("err = %v, want ErrNoQuorum (transient failures shouldn't cast votes)", err)
+	}
+	if contradiction {
+		t.Error("responses that never cast a vote should not count as a contradiction")
+	}
+}
+
+func TestTallyQuorumDivergentValues(t *testing.T) {
+	results := []callResult{{value: []byte("a")}, {value: []byte("b")}}
+	_, contradiction, err := tallyQuorum(results, 2)
+	if !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("err = %v, want ErrNoQuorum", err)
+	}
+	if !contradiction {
+		t.Error("two differing responses should be flagged as a contradiction")
+	}
+}
+
+func TestTallyQuorumMajorityWins(t *testing.T) {
+	results := []callResult{{value: []byte("a")}, {value: []byte("a")}, {value: []byte("b")}}
+	value, contradiction, err := tallyQuorum(results, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !contradiction {
+		t.Error("expected a contradiction to be flagged even though quorum was reached")
+	}
+	if string(value) != "a" {
+		t.Errorf("value = %q, want %q", value, "a")
+	}
+}
+
+func TestTallyQuorumMatchingRevertsAgreeDespiteWordingDifferences(t *testing.T) {
+	results := []callResult{
+		{err: errors.New("execution reverted: Insufficient balance")},
+		{err: errors.New("EXECUTION REVERTED: insufficient balance")},
+	}
+	_, _, err := tallyQuorum(results, 2)
+	if err == nil || errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("err = %v, want the normalized revert error surfaced instead of ErrNoQuorum", err)
+	}
+}
+
+func TestClassifyErr(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want errKind
+	}{
+		{"dial tcp 127.0.0.1:8545: connection refused", errKindTransient},
+		{"context deadline exceeded: timeout", errKindTransient},
+		{"execution reverted: insufficient balance", errKindSevere},
+		{"invalid argument", errKindSevere},
+	}
+	for _, tt := range tests {
+		if got := classifyErr(errors.New(tt.msg)); got != tt.want {
+			t.Errorf("classifyErr(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}